@@ -0,0 +1,139 @@
+package prettylog
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"sort"
+)
+
+// Color is an ANSI escape sequence used to style one token of pretty output.
+type Color string
+
+const (
+	colorReset Color = "\033[0m"
+
+	ColorGray    Color = "\033[90m"
+	ColorCyan    Color = "\033[36m"
+	ColorYellow  Color = "\033[33m"
+	ColorRed     Color = "\033[31m"
+	ColorDim     Color = "\033[2m"
+	ColorBold    Color = "\033[1m"
+	ColorGreen   Color = "\033[32m"
+	ColorMagenta Color = "\033[35m"
+	ColorBlue    Color = "\033[34m"
+)
+
+// defaultColorScheme is the per-level coloring applied when colorization is
+// enabled and the caller hasn't supplied their own via WithColorScheme.
+var defaultColorScheme = map[slog.Level]Color{
+	slog.LevelDebug: ColorGray,
+	slog.LevelInfo:  ColorCyan,
+	slog.LevelWarn:  ColorYellow,
+	slog.LevelError: ColorRed,
+}
+
+const (
+	colorTimestamp = ColorDim
+	colorMessage   = ColorBold
+	colorAttrKey   = ColorGreen
+	colorString    = ColorMagenta
+	colorNumber    = ColorBlue
+)
+
+func (c Color) wrap(s string) string {
+	if c == "" || s == "" {
+		return s
+	}
+	return string(c) + s + string(colorReset)
+}
+
+// WithColor enables or disables ANSI colorization of pretty output. When it
+// isn't called, colorization is auto-detected based on whether the
+// destination writer is a terminal.
+func WithColor(enabled bool) Option {
+	return func(h *Handler) {
+		h.colorEnabled = &enabled
+	}
+}
+
+// WithColorScheme overrides the default per-level color scheme used when
+// colorization is enabled. Levels absent from scheme fall back to the
+// default for that level.
+func WithColorScheme(scheme map[slog.Level]Color) Option {
+	return func(h *Handler) {
+		h.colorScheme = scheme
+	}
+}
+
+func (h *Handler) levelColor(level slog.Level) Color {
+	if c, ok := h.colorScheme[level]; ok {
+		return c
+	}
+	return defaultColorScheme[level]
+}
+
+// isTerminal reports whether w is a character device, i.e. a terminal.
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// colorizeAttrs renders attrs as a JSON-shaped object with sorted keys, like
+// json.Marshal, but with ANSI colors applied to keys and to string/number
+// values. Keys and strings are quoted with Go's %q, so characters that
+// json.Marshal escapes differently (such as <, > and & or control
+// characters) are not rendered identically.
+func colorizeAttrs(attrs map[string]any) string {
+	keys := make([]string, 0, len(attrs))
+	for k := range attrs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	out := "{"
+	for i, k := range keys {
+		if i > 0 {
+			out += ","
+		}
+		out += colorAttrKey.wrap(fmt.Sprintf("%q", k)) + ":" + colorizeValue(attrs[k])
+	}
+	out += "}"
+	return out
+}
+
+func colorizeValue(v any) string {
+	switch val := v.(type) {
+	case map[string]any:
+		return colorizeAttrs(val)
+	case []any:
+		out := "["
+		for i, e := range val {
+			if i > 0 {
+				out += ","
+			}
+			out += colorizeValue(e)
+		}
+		return out + "]"
+	case string:
+		return colorString.wrap(fmt.Sprintf("%q", val))
+	case float64:
+		// Use the same formatting as json.Marshal, not "%v": fmt switches
+		// to scientific notation far earlier than encoding/json does.
+		return colorNumber.wrap(formatJSONNumber(val))
+	case int, int64:
+		return colorNumber.wrap(fmt.Sprintf("%v", val))
+	case nil:
+		return "null"
+	default:
+		return fmt.Sprintf("%v", val)
+	}
+}
@@ -0,0 +1,82 @@
+package prettylog
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHandle_ColorDisabledByDefaultForNonTerminal(t *testing.T) {
+	var buf bytes.Buffer
+	h := New(nil, WithDestinationWriter(&buf))
+
+	r := slog.NewRecord(time.Now(), slog.LevelInfo, "msg", 0)
+	if err := h.Handle(context.Background(), r); err != nil {
+		t.Fatalf("Handle returned error: %v", err)
+	}
+
+	if strings.Contains(buf.String(), "\033[") {
+		t.Errorf("expected no ANSI codes when writer isn't a terminal, got: %q", buf.String())
+	}
+}
+
+func TestHandle_WithColorForcesEscapeCodes(t *testing.T) {
+	var buf bytes.Buffer
+	h := New(nil, WithDestinationWriter(&buf), WithColor(true))
+
+	r := slog.NewRecord(time.Now(), slog.LevelError, "boom", 0)
+	if err := h.Handle(context.Background(), r); err != nil {
+		t.Fatalf("Handle returned error: %v", err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, string(ColorRed)) {
+		t.Errorf("expected ERROR level colored red, got: %q", out)
+	}
+	if !strings.Contains(out, string(colorReset)) {
+		t.Errorf("expected a reset code in output, got: %q", out)
+	}
+}
+
+func TestHandle_WithColorSchemeOverride(t *testing.T) {
+	var buf bytes.Buffer
+	h := New(nil, WithDestinationWriter(&buf), WithColor(true), WithColorScheme(map[slog.Level]Color{
+		slog.LevelInfo: ColorMagenta,
+	}))
+
+	r := slog.NewRecord(time.Now(), slog.LevelInfo, "msg", 0)
+	if err := h.Handle(context.Background(), r); err != nil {
+		t.Fatalf("Handle returned error: %v", err)
+	}
+
+	if !strings.Contains(buf.String(), string(ColorMagenta)) {
+		t.Errorf("expected overridden INFO color, got: %q", buf.String())
+	}
+}
+
+func TestColorizeAttrs_FormatsLargeFloatsLikeJSON(t *testing.T) {
+	out := colorizeAttrs(map[string]any{"bytes": 100000000.0})
+	if !strings.Contains(out, "100000000") {
+		t.Errorf("expected a plain decimal number matching json.Marshal, got: %s", out)
+	}
+	if strings.Contains(out, "1e+08") {
+		t.Errorf("expected no scientific notation, got: %s", out)
+	}
+}
+
+func TestHandle_WithColorFalseDisablesAutoDetect(t *testing.T) {
+	var buf bytes.Buffer
+	h := New(nil, WithDestinationWriter(&buf), WithColor(false))
+
+	r := slog.NewRecord(time.Now(), slog.LevelInfo, "msg", 0)
+	if err := h.Handle(context.Background(), r); err != nil {
+		t.Fatalf("Handle returned error: %v", err)
+	}
+
+	if strings.Contains(buf.String(), "\033[") {
+		t.Errorf("expected no ANSI codes when WithColor(false), got: %q", buf.String())
+	}
+}
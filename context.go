@@ -0,0 +1,41 @@
+package prettylog
+
+import (
+	"context"
+	"log/slog"
+)
+
+// WithContextExtractor registers a function that pulls attrs out of a
+// record's context (e.g. a request-scoped trace ID) and prepends them to
+// the record before it's handed to the inner handler. Multiple extractors
+// compose: each call adds another extractor rather than replacing prior
+// ones, and their results are prepended in registration order.
+func WithContextExtractor(extractor func(context.Context) []slog.Attr) Option {
+	return func(h *Handler) {
+		h.contextExtractors = append(h.contextExtractors, extractor)
+	}
+}
+
+// withExtractedAttrs returns r with the attrs produced by h.contextExtractors
+// prepended ahead of r's own attrs, or r unchanged if there are none.
+func (h *Handler) withExtractedAttrs(ctx context.Context, r slog.Record) slog.Record {
+	if len(h.contextExtractors) == 0 {
+		return r
+	}
+
+	var extracted []slog.Attr
+	for _, extractor := range h.contextExtractors {
+		extracted = append(extracted, extractor(ctx)...)
+	}
+	if len(extracted) == 0 {
+		return r
+	}
+
+	merged := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+	merged.AddAttrs(extracted...)
+	r.Attrs(func(a slog.Attr) bool {
+		merged.AddAttrs(a)
+		return true
+	})
+	return merged
+}
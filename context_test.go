@@ -0,0 +1,72 @@
+package prettylog
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+type traceIDKey struct{}
+
+func TestHandle_WithContextExtractor(t *testing.T) {
+	var buf bytes.Buffer
+	h := New(nil, WithDestinationWriter(&buf), WithContextExtractor(func(ctx context.Context) []slog.Attr {
+		id, _ := ctx.Value(traceIDKey{}).(string)
+		if id == "" {
+			return nil
+		}
+		return []slog.Attr{slog.String("trace_id", id)}
+	}))
+
+	ctx := context.WithValue(context.Background(), traceIDKey{}, "abc123")
+	r := slog.NewRecord(time.Now(), slog.LevelInfo, "msg", 0)
+
+	if err := h.Handle(ctx, r); err != nil {
+		t.Fatalf("Handle returned error: %v", err)
+	}
+
+	if !strings.Contains(buf.String(), `"trace_id":"abc123"`) {
+		t.Errorf("expected trace_id attr from context extractor, got: %s", buf.String())
+	}
+}
+
+func TestHandle_MultipleContextExtractorsCompose(t *testing.T) {
+	var buf bytes.Buffer
+	h := New(nil, WithDestinationWriter(&buf),
+		WithContextExtractor(func(context.Context) []slog.Attr {
+			return []slog.Attr{slog.String("a", "1")}
+		}),
+		WithContextExtractor(func(context.Context) []slog.Attr {
+			return []slog.Attr{slog.String("b", "2")}
+		}),
+	)
+
+	r := slog.NewRecord(time.Now(), slog.LevelInfo, "msg", 0)
+	if err := h.Handle(context.Background(), r); err != nil {
+		t.Fatalf("Handle returned error: %v", err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, `"a":"1"`) || !strings.Contains(out, `"b":"2"`) {
+		t.Errorf("expected attrs from both extractors, got: %s", out)
+	}
+}
+
+func TestHandle_NoContextExtractorsLeavesRecordUnchanged(t *testing.T) {
+	var buf bytes.Buffer
+	h := New(nil, WithDestinationWriter(&buf))
+
+	r := slog.NewRecord(time.Now(), slog.LevelInfo, "msg", 0)
+	r.AddAttrs(slog.String("key", "value"))
+
+	if err := h.Handle(context.Background(), r); err != nil {
+		t.Fatalf("Handle returned error: %v", err)
+	}
+
+	if !strings.Contains(buf.String(), `"key":"value"`) {
+		t.Errorf("expected original attr to survive unchanged, got: %s", buf.String())
+	}
+}
@@ -0,0 +1,168 @@
+package prettylog
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	pkgerrors "github.com/pkg/errors"
+)
+
+// defaultTruncateThreshold is how many bytes a string attr value may reach
+// before WithExpandAttrs(false) mode truncates it.
+const defaultTruncateThreshold = 2048
+
+// WithExpandAttrs renders attrs across indented continuation lines instead
+// of a single trailing JSON blob: one key per line, with multi-line values
+// (stack traces, SQL queries, JSON payloads) indented underneath using
+// box-drawing prefixes.
+func WithExpandAttrs(enabled bool) Option {
+	return func(h *Handler) {
+		h.expandAttrs = enabled
+	}
+}
+
+// WithTruncateThreshold sets how many bytes a string attr value may reach
+// before it's truncated with a "… (N more bytes)" marker. Has no effect
+// when expansion is enabled, since expanded output always shows values in
+// full.
+func WithTruncateThreshold(bytes int) Option {
+	return func(h *Handler) {
+		h.truncateThreshold = bytes
+	}
+}
+
+// stackTracer is the pkg/errors convention for an error that carries a
+// captured stack trace.
+type stackTracer interface {
+	StackTrace() pkgerrors.StackTrace
+}
+
+// expandedErrors walks r's top-level attrs for error values that implement
+// stackTracer and renders their frames. This has to run against the live
+// record rather than the attrs map, since by the time attrs reach
+// expandAttrsBlock they've been round-tripped through JSON and are plain
+// strings.
+func (h *Handler) expandedErrors(r slog.Record) map[string]string {
+	var out map[string]string
+	r.Attrs(func(a slog.Attr) bool {
+		err, ok := a.Value.Any().(error)
+		if !ok {
+			return true
+		}
+		st, ok := err.(stackTracer)
+		if !ok {
+			return true
+		}
+		if out == nil {
+			out = map[string]string{}
+		}
+		out[a.Key] = renderStackTrace(err, st)
+		return true
+	})
+	return out
+}
+
+func renderStackTrace(err error, st stackTracer) string {
+	var b strings.Builder
+	b.WriteString(err.Error())
+	for _, frame := range st.StackTrace() {
+		fmt.Fprintf(&b, "\n%+v", frame)
+	}
+	return b.String()
+}
+
+// expandAttrsBlock renders attrs one key per line instead of a single JSON
+// blob. errs supplies richer, multi-line renderings (e.g. stack traces) for
+// specific keys, overriding their plain attrs value.
+func (h *Handler) expandAttrsBlock(attrs map[string]any, errs map[string]string) string {
+	keys := make([]string, 0, len(attrs))
+	for k := range attrs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for i, k := range keys {
+		prefix := "├─"
+		cont := "│ "
+		if i == len(keys)-1 {
+			prefix = "└─"
+			cont = "  "
+		}
+
+		rendered, ok := errs[k]
+		if !ok {
+			rendered = renderExpandedValue(attrs[k])
+		}
+
+		lines := strings.Split(rendered, "\n")
+		if len(lines) == 1 {
+			fmt.Fprintf(&b, "\n%s %s: %s", prefix, k, lines[0])
+			continue
+		}
+
+		fmt.Fprintf(&b, "\n%s %s:", prefix, k)
+		for _, line := range lines {
+			b.WriteString("\n" + cont + "  " + line)
+		}
+	}
+	return b.String()
+}
+
+func renderExpandedValue(v any) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(b)
+}
+
+// truncateLargeStringsInMap returns a copy of attrs with any string value
+// over threshold bytes truncated and marked with how much was cut.
+func truncateLargeStringsInMap(attrs map[string]any, threshold int) map[string]any {
+	out := make(map[string]any, len(attrs))
+	for k, v := range attrs {
+		out[k] = truncateLargeStrings(v, threshold)
+	}
+	return out
+}
+
+func truncateLargeStrings(v any, threshold int) any {
+	switch val := v.(type) {
+	case string:
+		if len(val) <= threshold {
+			return val
+		}
+		cut := truncationBoundary(val, threshold)
+		return fmt.Sprintf("%s… (%d more bytes)", val[:cut], len(val)-cut)
+	case map[string]any:
+		return truncateLargeStringsInMap(val, threshold)
+	case []any:
+		out := make([]any, len(val))
+		for i, e := range val {
+			out[i] = truncateLargeStrings(e, threshold)
+		}
+		return out
+	default:
+		return val
+	}
+}
+
+// truncationBoundary backs threshold up to the start of the rune it falls
+// in the middle of, if any, so callers never slice a string mid-rune. This
+// matters beyond cosmetics: CBORFormatter emits the result as a CBOR
+// major-type-3 text string, which RFC 8949 requires to be valid UTF-8.
+func truncationBoundary(s string, threshold int) int {
+	cut := threshold
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return cut
+}
@@ -0,0 +1,126 @@
+package prettylog
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	pkgerrors "github.com/pkg/errors"
+)
+
+func TestHandle_WithExpandAttrsRendersOnePerLine(t *testing.T) {
+	var buf bytes.Buffer
+	h := New(nil, WithDestinationWriter(&buf), WithExpandAttrs(true))
+
+	r := slog.NewRecord(time.Now(), slog.LevelInfo, "msg", 0)
+	r.AddAttrs(slog.String("a", "1"), slog.String("b", "2"))
+
+	if err := h.Handle(context.Background(), r); err != nil {
+		t.Fatalf("Handle returned error: %v", err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, "├─ a: 1") {
+		t.Errorf("expected a mid-tree branch for the first key, got: %s", out)
+	}
+	if !strings.Contains(out, "└─ b: 2") {
+		t.Errorf("expected a terminal branch for the last key, got: %s", out)
+	}
+}
+
+func TestHandle_WithExpandAttrsIndentsMultilineValues(t *testing.T) {
+	var buf bytes.Buffer
+	h := New(nil, WithDestinationWriter(&buf), WithExpandAttrs(true))
+
+	r := slog.NewRecord(time.Now(), slog.LevelInfo, "msg", 0)
+	r.AddAttrs(slog.String("query", "SELECT *\nFROM users"))
+
+	if err := h.Handle(context.Background(), r); err != nil {
+		t.Fatalf("Handle returned error: %v", err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, "└─ query:") {
+		t.Errorf("expected the key on its own line, got: %s", out)
+	}
+	if !strings.Contains(out, "SELECT *") || !strings.Contains(out, "FROM users") {
+		t.Errorf("expected both lines of the value, got: %s", out)
+	}
+}
+
+func TestHandle_TruncatesLargeStringValuesByDefault(t *testing.T) {
+	var buf bytes.Buffer
+	h := New(nil, WithDestinationWriter(&buf), WithTruncateThreshold(8))
+
+	r := slog.NewRecord(time.Now(), slog.LevelInfo, "msg", 0)
+	r.AddAttrs(slog.String("payload", "0123456789"))
+
+	if err := h.Handle(context.Background(), r); err != nil {
+		t.Fatalf("Handle returned error: %v", err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, "01234567") || !strings.Contains(out, "more bytes") {
+		t.Errorf("expected a truncated payload with a more-bytes marker, got: %s", out)
+	}
+	if strings.Contains(out, "0123456789") {
+		t.Errorf("expected the full value not to survive truncation, got: %s", out)
+	}
+}
+
+func TestHandle_WithExpandAttrsSkipsTruncation(t *testing.T) {
+	var buf bytes.Buffer
+	h := New(nil, WithDestinationWriter(&buf), WithExpandAttrs(true), WithTruncateThreshold(8))
+
+	r := slog.NewRecord(time.Now(), slog.LevelInfo, "msg", 0)
+	r.AddAttrs(slog.String("payload", "0123456789"))
+
+	if err := h.Handle(context.Background(), r); err != nil {
+		t.Fatalf("Handle returned error: %v", err)
+	}
+
+	if !strings.Contains(buf.String(), "0123456789") {
+		t.Errorf("expected the full value when expansion is enabled, got: %s", buf.String())
+	}
+}
+
+func TestTruncateLargeStrings_DoesNotSplitAMultibyteRune(t *testing.T) {
+	// "héllo" is h(1) + é(2 bytes) + llo(3); threshold=2 would land inside é
+	// if we sliced by raw byte offset instead of backing up to a boundary.
+	out := truncateLargeStrings("héllo world, this needs to be long enough to trip truncation", 2)
+	s, ok := out.(string)
+	if !ok {
+		t.Fatalf("expected a string result, got: %T", out)
+	}
+	if !utf8.ValidString(s) {
+		t.Errorf("expected valid UTF-8 after truncation, got invalid string: %q", s)
+	}
+	if !strings.HasPrefix(s, "h") {
+		t.Errorf("expected truncation to back up before the multi-byte rune, got: %q", s)
+	}
+}
+
+func TestHandle_WithExpandAttrsRendersStackTrace(t *testing.T) {
+	var buf bytes.Buffer
+	h := New(nil, WithDestinationWriter(&buf), WithExpandAttrs(true))
+
+	err := pkgerrors.New("boom")
+	r := slog.NewRecord(time.Now(), slog.LevelError, "failed", 0)
+	r.AddAttrs(slog.Any("error", err))
+
+	if handleErr := h.Handle(context.Background(), r); handleErr != nil {
+		t.Fatalf("Handle returned error: %v", handleErr)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, "boom") {
+		t.Errorf("expected the error message in output, got: %s", out)
+	}
+	if !strings.Contains(out, "expand_test.go") {
+		t.Errorf("expected a stack frame referencing this test file, got: %s", out)
+	}
+}
@@ -0,0 +1,227 @@
+package prettylog
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Formatter serializes a record's attributes into the trailing blob that
+// follows the timestamp/level/message on each pretty log line.
+type Formatter interface {
+	FormatAttrs(attrs map[string]any) ([]byte, error)
+}
+
+// WithFormatter overrides the Formatter used to render attrs. Defaults to
+// JSONFormatter.
+func WithFormatter(f Formatter) Option {
+	return func(h *Handler) {
+		h.formatter = f
+	}
+}
+
+// JSONFormatter renders attrs as a single JSON object, matching the
+// package's original behavior.
+type JSONFormatter struct{}
+
+func (JSONFormatter) FormatAttrs(attrs map[string]any) ([]byte, error) {
+	return json.Marshal(attrs)
+}
+
+// LogfmtFormatter renders attrs as space-separated key=value pairs,
+// quoting values that contain whitespace or logfmt-reserved characters.
+type LogfmtFormatter struct{}
+
+func (LogfmtFormatter) FormatAttrs(attrs map[string]any) ([]byte, error) {
+	keys := make([]string, 0, len(attrs))
+	for k := range attrs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for i, k := range keys {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(logfmtQuote(k))
+		b.WriteByte('=')
+		b.WriteString(logfmtValue(attrs[k]))
+	}
+	return []byte(b.String()), nil
+}
+
+func logfmtValue(v any) string {
+	switch val := v.(type) {
+	case string:
+		return logfmtQuote(val)
+	case nil:
+		return ""
+	case float64:
+		return formatJSONNumber(val)
+	case map[string]any, []any:
+		b, err := json.Marshal(val)
+		if err != nil {
+			return logfmtQuote(fmt.Sprintf("%v", val))
+		}
+		return logfmtQuote(string(b))
+	default:
+		return fmt.Sprintf("%v", val)
+	}
+}
+
+// formatJSONNumber renders f exactly the way encoding/json would, so
+// colorizeAttrs and LogfmtFormatter render numbers the way json.Marshal
+// does. json.Marshal never uses
+// exponential notation until the magnitude genuinely needs it, unlike
+// fmt's "%v"/"%g", which switch to scientific notation far earlier.
+func formatJSONNumber(f float64) string {
+	b, err := json.Marshal(f)
+	if err != nil {
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	}
+	return string(b)
+}
+
+func logfmtQuote(s string) string {
+	if !needsLogfmtQuoting(s) {
+		return s
+	}
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			b.WriteString(`\"`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '\n':
+			b.WriteString(`\n`)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+func needsLogfmtQuoting(s string) bool {
+	if s == "" {
+		return true
+	}
+	for _, r := range s {
+		if r <= ' ' || r == '"' || r == '=' || r == '\\' {
+			return true
+		}
+	}
+	return false
+}
+
+// CBORFormatter renders attrs as CBOR (RFC 8949), wrapped in a
+// data:application/cbor;base64 URL so it survives text-only log pipelines.
+type CBORFormatter struct{}
+
+func (CBORFormatter) FormatAttrs(attrs map[string]any) ([]byte, error) {
+	enc, err := encodeCBORMap(attrs)
+	if err != nil {
+		return nil, fmt.Errorf("error when encoding attrs as cbor: %w", err)
+	}
+	url := "data:application/cbor;base64," + base64.StdEncoding.EncodeToString(enc)
+	return []byte(url), nil
+}
+
+func encodeCBORValue(v any) ([]byte, error) {
+	switch val := v.(type) {
+	case nil:
+		return []byte{0xf6}, nil
+	case bool:
+		if val {
+			return []byte{0xf5}, nil
+		}
+		return []byte{0xf4}, nil
+	case string:
+		return append(cborHead(3, uint64(len(val))), val...), nil
+	case float64:
+		return encodeCBORFloat(val), nil
+	case int:
+		return encodeCBORInt(int64(val)), nil
+	case int64:
+		return encodeCBORInt(val), nil
+	case map[string]any:
+		return encodeCBORMap(val)
+	case []any:
+		out := cborHead(4, uint64(len(val)))
+		for _, e := range val {
+			b, err := encodeCBORValue(e)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, b...)
+		}
+		return out, nil
+	default:
+		s := fmt.Sprintf("%v", val)
+		return append(cborHead(3, uint64(len(s))), s...), nil
+	}
+}
+
+func encodeCBORMap(m map[string]any) ([]byte, error) {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	out := cborHead(5, uint64(len(keys)))
+	for _, k := range keys {
+		out = append(out, cborHead(3, uint64(len(k)))...)
+		out = append(out, k...)
+		v, err := encodeCBORValue(m[k])
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, v...)
+	}
+	return out, nil
+}
+
+func encodeCBORInt(n int64) []byte {
+	if n >= 0 {
+		return cborHead(0, uint64(n))
+	}
+	return cborHead(1, uint64(-1-n))
+}
+
+func encodeCBORFloat(f float64) []byte {
+	out := []byte{0xfb}
+	bits := math.Float64bits(f)
+	for i := 7; i >= 0; i-- {
+		out = append(out, byte(bits>>(8*i)))
+	}
+	return out
+}
+
+// cborHead encodes a CBOR major type (0-7) and its argument n, choosing the
+// shortest representation per RFC 8949 section 3.
+func cborHead(major byte, n uint64) []byte {
+	switch {
+	case n < 24:
+		return []byte{major<<5 | byte(n)}
+	case n <= 0xff:
+		return []byte{major<<5 | 24, byte(n)}
+	case n <= 0xffff:
+		return []byte{major<<5 | 25, byte(n >> 8), byte(n)}
+	case n <= 0xffffffff:
+		return []byte{major<<5 | 26, byte(n >> 24), byte(n >> 16), byte(n >> 8), byte(n)}
+	default:
+		return []byte{
+			major<<5 | 27,
+			byte(n >> 56), byte(n >> 48), byte(n >> 40), byte(n >> 32),
+			byte(n >> 24), byte(n >> 16), byte(n >> 8), byte(n),
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package prettylog
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHandle_WithFormatterLogfmt(t *testing.T) {
+	var buf bytes.Buffer
+	h := New(nil, WithDestinationWriter(&buf), WithFormatter(LogfmtFormatter{}))
+
+	r := slog.NewRecord(time.Now(), slog.LevelInfo, "msg", 0)
+	r.AddAttrs(slog.String("key", "value"), slog.Int("count", 42))
+
+	if err := h.Handle(context.Background(), r); err != nil {
+		t.Fatalf("Handle returned error: %v", err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, "key=value") {
+		t.Errorf("expected logfmt key=value, got: %s", out)
+	}
+	if !strings.Contains(out, "count=42") {
+		t.Errorf("expected logfmt count=42, got: %s", out)
+	}
+}
+
+func TestLogfmtFormatter_QuotesValuesWithSpaces(t *testing.T) {
+	f := LogfmtFormatter{}
+	b, err := f.FormatAttrs(map[string]any{"msg": "hello world"})
+	if err != nil {
+		t.Fatalf("FormatAttrs returned error: %v", err)
+	}
+	if string(b) != `msg="hello world"` {
+		t.Errorf("expected quoted value, got: %s", b)
+	}
+}
+
+func TestHandle_WithFormatterCBOR(t *testing.T) {
+	var buf bytes.Buffer
+	h := New(nil, WithDestinationWriter(&buf), WithFormatter(CBORFormatter{}))
+
+	r := slog.NewRecord(time.Now(), slog.LevelInfo, "msg", 0)
+	r.AddAttrs(slog.String("key", "value"))
+
+	if err := h.Handle(context.Background(), r); err != nil {
+		t.Fatalf("Handle returned error: %v", err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, "data:application/cbor;base64,") {
+		t.Errorf("expected a cbor data URL, got: %s", out)
+	}
+}
+
+func TestLogfmtFormatter_FormatsLargeFloatsLikeJSON(t *testing.T) {
+	f := LogfmtFormatter{}
+	b, err := f.FormatAttrs(map[string]any{"bytes": 100000000.0})
+	if err != nil {
+		t.Fatalf("FormatAttrs returned error: %v", err)
+	}
+	if string(b) != "bytes=100000000" {
+		t.Errorf("expected a plain decimal number matching json.Marshal, got: %s", b)
+	}
+}
+
+func TestLogfmtFormatter_FormatsSmallFractionalsLikeJSON(t *testing.T) {
+	f := LogfmtFormatter{}
+	b, err := f.FormatAttrs(map[string]any{"ratio": 0.00001})
+	if err != nil {
+		t.Fatalf("FormatAttrs returned error: %v", err)
+	}
+	want, _ := json.Marshal(0.00001)
+	if string(b) != "ratio="+string(want) {
+		t.Errorf("expected logfmt number to match json.Marshal's rendering, got: %s, want: ratio=%s", b, want)
+	}
+}
+
+func TestCBORFormatter_RoundTripsKnownEncoding(t *testing.T) {
+	f := CBORFormatter{}
+	b, err := f.FormatAttrs(map[string]any{"key": "value"})
+	if err != nil {
+		t.Fatalf("FormatAttrs returned error: %v", err)
+	}
+
+	prefix := "data:application/cbor;base64,"
+	if !strings.HasPrefix(string(b), prefix) {
+		t.Fatalf("expected data URL prefix, got: %s", b)
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(string(b), prefix))
+	if err != nil {
+		t.Fatalf("failed to decode base64: %v", err)
+	}
+
+	// map(1){"key": "value"} per RFC 8949.
+	want := []byte{0xa1, 0x63, 'k', 'e', 'y', 0x65, 'v', 'a', 'l', 'u', 'e'}
+	if !bytes.Equal(raw, want) {
+		t.Errorf("unexpected cbor encoding: % x, want % x", raw, want)
+	}
+}
@@ -0,0 +1,90 @@
+package prettylog
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+)
+
+// multiHandler fans a single record out to several slog.Handlers.
+type multiHandler struct {
+	handlers []slog.Handler
+}
+
+// Multi returns a slog.Handler that dispatches every record to all of the
+// given handlers, e.g. a pretty handler writing to stdout alongside a JSON
+// handler writing to a file or an OTLP exporter.
+func Multi(handlers ...slog.Handler) slog.Handler {
+	return &multiHandler{handlers: handlers}
+}
+
+func (m *multiHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	for _, h := range m.handlers {
+		if h.Enabled(ctx, level) {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *multiHandler) Handle(ctx context.Context, r slog.Record) error {
+	var errs []error
+	for _, h := range m.handlers {
+		if !h.Enabled(ctx, r.Level) {
+			continue
+		}
+		if err := h.Handle(ctx, r.Clone()); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (m *multiHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	next := make([]slog.Handler, len(m.handlers))
+	for i, h := range m.handlers {
+		next[i] = h.WithAttrs(attrs)
+	}
+	return &multiHandler{handlers: next}
+}
+
+func (m *multiHandler) WithGroup(name string) slog.Handler {
+	next := make([]slog.Handler, len(m.handlers))
+	for i, h := range m.handlers {
+		next[i] = h.WithGroup(name)
+	}
+	return &multiHandler{handlers: next}
+}
+
+func withAttrsFanout(fanout []slog.Handler, attrs []slog.Attr) []slog.Handler {
+	if len(fanout) == 0 {
+		return fanout
+	}
+	next := make([]slog.Handler, len(fanout))
+	for i, fh := range fanout {
+		next[i] = fh.WithAttrs(attrs)
+	}
+	return next
+}
+
+func withGroupFanout(fanout []slog.Handler, name string) []slog.Handler {
+	if len(fanout) == 0 {
+		return fanout
+	}
+	next := make([]slog.Handler, len(fanout))
+	for i, fh := range fanout {
+		next[i] = fh.WithGroup(name)
+	}
+	return next
+}
+
+// WithFanout dispatches every record the Handler handles to the given
+// handlers as well, e.g. a JSON file handler or an OTLP exporter running
+// alongside the human-readable output. Each child receives the records its
+// own Enabled accepts, including records below the Handler's own level that
+// are not pretty-printed.
+func WithFanout(handlers ...slog.Handler) Option {
+	return func(h *Handler) {
+		h.fanout = append(h.fanout, handlers...)
+	}
+}
@@ -0,0 +1,94 @@
+package prettylog
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMulti_DispatchesToAllHandlers(t *testing.T) {
+	var bufA, bufB bytes.Buffer
+	m := Multi(slog.NewJSONHandler(&bufA, nil), slog.NewJSONHandler(&bufB, nil))
+
+	r := slog.NewRecord(time.Now(), slog.LevelInfo, "hello", 0)
+	if err := m.Handle(context.Background(), r); err != nil {
+		t.Fatalf("Handle returned error: %v", err)
+	}
+
+	if !strings.Contains(bufA.String(), "hello") {
+		t.Errorf("expected handler A to receive the record, got: %s", bufA.String())
+	}
+	if !strings.Contains(bufB.String(), "hello") {
+		t.Errorf("expected handler B to receive the record, got: %s", bufB.String())
+	}
+}
+
+func TestMulti_WithAttrsPropagatesToChildren(t *testing.T) {
+	var buf bytes.Buffer
+	m := Multi(slog.NewJSONHandler(&buf, nil))
+
+	m2 := m.WithAttrs([]slog.Attr{slog.String("service", "api")})
+
+	r := slog.NewRecord(time.Now(), slog.LevelInfo, "hello", 0)
+	if err := m2.Handle(context.Background(), r); err != nil {
+		t.Fatalf("Handle returned error: %v", err)
+	}
+
+	if !strings.Contains(buf.String(), `"service":"api"`) {
+		t.Errorf("expected WithAttrs to propagate to the child handler, got: %s", buf.String())
+	}
+}
+
+func TestHandle_WithFanoutDispatchesToChildHandler(t *testing.T) {
+	var pretty, fanout bytes.Buffer
+	h := New(nil, WithDestinationWriter(&pretty), WithFanout(slog.NewJSONHandler(&fanout, nil)))
+
+	r := slog.NewRecord(time.Now(), slog.LevelInfo, "hello", 0)
+	if err := h.Handle(context.Background(), r); err != nil {
+		t.Fatalf("Handle returned error: %v", err)
+	}
+
+	if !strings.Contains(pretty.String(), "hello") {
+		t.Errorf("expected pretty output, got: %s", pretty.String())
+	}
+	if !strings.Contains(fanout.String(), `"msg":"hello"`) {
+		t.Errorf("expected fanout handler to receive a JSON record, got: %s", fanout.String())
+	}
+}
+
+func TestHandle_WithFanoutDoesNotLeakBelowOwnLevelToPrettyWriter(t *testing.T) {
+	var pretty, fanout bytes.Buffer
+	h := New(&slog.HandlerOptions{Level: slog.LevelInfo}, WithDestinationWriter(&pretty),
+		WithFanout(slog.NewJSONHandler(&fanout, &slog.HandlerOptions{Level: slog.LevelDebug})))
+
+	r := slog.NewRecord(time.Now(), slog.LevelDebug, "debug detail", 0)
+	if err := h.Handle(context.Background(), r); err != nil {
+		t.Fatalf("Handle returned error: %v", err)
+	}
+
+	if pretty.Len() != 0 {
+		t.Errorf("expected no pretty output below the handler's own level, got: %s", pretty.String())
+	}
+	if !strings.Contains(fanout.String(), "debug detail") {
+		t.Errorf("expected the more permissive fanout child to still receive the record, got: %s", fanout.String())
+	}
+}
+
+func TestHandle_WithFanoutAppliesWithAttrs(t *testing.T) {
+	var pretty, fanout bytes.Buffer
+	h := New(nil, WithDestinationWriter(&pretty), WithFanout(slog.NewJSONHandler(&fanout, nil)))
+
+	h2 := h.WithAttrs([]slog.Attr{slog.String("service", "api")})
+
+	r := slog.NewRecord(time.Now(), slog.LevelInfo, "hello", 0)
+	if err := h2.Handle(context.Background(), r); err != nil {
+		t.Fatalf("Handle returned error: %v", err)
+	}
+
+	if !strings.Contains(fanout.String(), `"service":"api"`) {
+		t.Errorf("expected fanout child to pick up WithAttrs, got: %s", fanout.String())
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
@@ -17,42 +18,107 @@ const (
 	timeFormat = "[15:04:05.000]"
 )
 
+// innerHandlerStep applies one accumulated WithAttrs or WithGroup call to a
+// freshly built inner handler, so the chain can be replayed against a new
+// buffer on every Handle call instead of being bound to a shared one.
+type innerHandlerStep func(slog.Handler) slog.Handler
+
+var bufPool = sync.Pool{
+	New: func() any { return new(bytes.Buffer) },
+}
+
 type Handler struct {
-	h                slog.Handler
-	r                func([]string, slog.Attr) slog.Attr
-	b                *bytes.Buffer
-	m                *sync.Mutex
-	writer           io.Writer
-	outputEmptyAttrs bool
+	opts              *slog.HandlerOptions
+	steps             []innerHandlerStep
+	r                 func([]string, slog.Attr) slog.Attr
+	writer            io.Writer
+	outputEmptyAttrs  bool
+	colorEnabled      *bool
+	colorScheme       map[slog.Level]Color
+	color             bool
+	formatter         Formatter
+	addSource         bool
+	sourceFormatter   func(*slog.Source) string
+	contextExtractors []func(context.Context) []slog.Attr
+	fanout            []slog.Handler
+	expandAttrs       bool
+	truncateThreshold int
+}
+
+// ownEnabled reports whether level clears this handler's own configured
+// level, independent of any fanout children. Enabled() ORs this with the
+// fanout children so slog still dispatches to Handle for a record a child
+// wants, but Handle itself must gate the pretty-print path on ownEnabled
+// so a more permissive fanout child doesn't leak low-level records into
+// the primary writer.
+func (h *Handler) ownEnabled(level slog.Level) bool {
+	minLevel := slog.LevelInfo
+	if h.opts.Level != nil {
+		minLevel = h.opts.Level.Level()
+	}
+	return level >= minLevel
 }
 
 func (h *Handler) Enabled(ctx context.Context, level slog.Level) bool {
-	return h.h.Enabled(ctx, level)
+	if h.ownEnabled(level) {
+		return true
+	}
+	for _, fh := range h.fanout {
+		if fh.Enabled(ctx, level) {
+			return true
+		}
+	}
+	return false
+}
+
+func (h *Handler) clone() *Handler {
+	c := *h
+	c.steps = append([]innerHandlerStep{}, h.steps...)
+	return &c
 }
 
 func (h *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
-	return &Handler{h: h.h.WithAttrs(attrs), b: h.b, r: h.r, m: h.m, writer: h.writer, outputEmptyAttrs: h.outputEmptyAttrs}
+	c := h.clone()
+	c.steps = append(c.steps, func(in slog.Handler) slog.Handler { return in.WithAttrs(attrs) })
+	c.fanout = withAttrsFanout(h.fanout, attrs)
+	return c
 }
 
 func (h *Handler) WithGroup(name string) slog.Handler {
-	return &Handler{h: h.h.WithGroup(name), b: h.b, r: h.r, m: h.m, writer: h.writer, outputEmptyAttrs: h.outputEmptyAttrs}
+	c := h.clone()
+	c.steps = append(c.steps, func(in slog.Handler) slog.Handler { return in.WithGroup(name) })
+	c.fanout = withGroupFanout(h.fanout, name)
+	return c
+}
+
+// newInnerHandler builds a throwaway slog.JSONHandler pointed at buf and
+// replays the accumulated WithAttrs/WithGroup chain onto it.
+func (h *Handler) newInnerHandler(buf *bytes.Buffer) slog.Handler {
+	inner := slog.Handler(slog.NewJSONHandler(buf, &slog.HandlerOptions{
+		Level:       h.opts.Level,
+		AddSource:   h.opts.AddSource,
+		ReplaceAttr: suppressDefaults(h.opts.ReplaceAttr),
+	}))
+	for _, step := range h.steps {
+		inner = step(inner)
+	}
+	return inner
 }
 
 func (h *Handler) computeAttrs(
 	ctx context.Context,
 	r slog.Record,
 ) (map[string]any, error) {
-	h.m.Lock()
-	defer func() {
-		h.b.Reset()
-		h.m.Unlock()
-	}()
-	if err := h.h.Handle(ctx, r); err != nil {
+	buf := bufPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	defer bufPool.Put(buf)
+
+	if err := h.newInnerHandler(buf).Handle(ctx, r); err != nil {
 		return nil, fmt.Errorf("error when calling inner handler's Handle: %w", err)
 	}
 
 	var attrs map[string]any
-	err := json.Unmarshal(h.b.Bytes(), &attrs)
+	err := json.Unmarshal(buf.Bytes(), &attrs)
 	if err != nil {
 		return nil, fmt.Errorf("error when unmarshaling inner handler's Handle result: %w", err)
 	}
@@ -60,7 +126,29 @@ func (h *Handler) computeAttrs(
 }
 
 func (h *Handler) Handle(ctx context.Context, r slog.Record) error {
+	r = h.withExtractedAttrs(ctx, r)
+
+	if h.ownEnabled(r.Level) {
+		if err := h.writePretty(ctx, r); err != nil {
+			return err
+		}
+	}
+
+	var fanoutErrs []error
+	for _, fh := range h.fanout {
+		if !fh.Enabled(ctx, r.Level) {
+			continue
+		}
+		if err := fh.Handle(ctx, r.Clone()); err != nil {
+			fanoutErrs = append(fanoutErrs, err)
+		}
+	}
+	return errors.Join(fanoutErrs...)
+}
 
+// writePretty renders r as a pretty line and writes it to h.writer. It's
+// gated by h.ownEnabled in Handle, separately from fanout dispatch.
+func (h *Handler) writePretty(ctx context.Context, r slog.Record) error {
 	var level string
 	levelAttr := slog.Attr{
 		Key:   slog.LevelKey,
@@ -107,40 +195,73 @@ func (h *Handler) Handle(ctx context.Context, r slog.Record) error {
 		return err
 	}
 
-	var attrsAsBytes []byte
+	var source string
+	if h.addSource {
+		source = h.extractSource(attrs)
+	}
+
+	var attrsAsString string
 	if h.outputEmptyAttrs || len(attrs) > 0 {
-		attrsAsBytes, err = json.Marshal(attrs)
-		if err != nil {
-			return fmt.Errorf("error when marshaling attrs: %w", err)
+		switch {
+		case h.expandAttrs:
+			attrsAsString = h.expandAttrsBlock(attrs, h.expandedErrors(r))
+		default:
+			if h.truncateThreshold > 0 {
+				attrs = truncateLargeStringsInMap(attrs, h.truncateThreshold)
+			}
+			if _, isJSON := h.formatter.(JSONFormatter); h.color && isJSON {
+				attrsAsString = colorizeAttrs(attrs)
+			} else {
+				attrsAsBytes, err := h.formatter.FormatAttrs(attrs)
+				if err != nil {
+					return fmt.Errorf("error when formatting attrs: %w", err)
+				}
+				attrsAsString = string(attrsAsBytes)
+			}
 		}
 	}
 
 	out := strings.Builder{}
 	if len(timestamp) > 0 {
+		if h.color {
+			timestamp = colorTimestamp.wrap(timestamp)
+		}
 		out.WriteString(timestamp)
 		out.WriteString(" ")
 	}
 	if len(level) > 0 {
-		// padd left level to 7 characters for better alignment
-		fmt.Fprintf(&out, "%7s", level)
+		// padd left level to 7 characters for better alignment before
+		// wrapping in color, so escape codes don't skew the width
+		level = fmt.Sprintf("%7s", level)
+		if h.color {
+			level = h.levelColor(r.Level).wrap(level)
+		}
+		out.WriteString(level)
+		out.WriteString(" ")
+	}
+
+	if len(source) > 0 {
+		if h.color {
+			source = ColorDim.wrap(source)
+		}
+		out.WriteString(source)
 		out.WriteString(" ")
 	}
 
 	if len(msg) > 0 {
+		if h.color {
+			msg = colorMessage.wrap(msg)
+		}
 		out.WriteString(msg)
 		out.WriteString(" ")
 	}
 
-	if len(attrsAsBytes) > 0 {
-		out.WriteString(string(attrsAsBytes))
+	if len(attrsAsString) > 0 {
+		out.WriteString(attrsAsString)
 	}
 
 	_, err = io.WriteString(h.writer, out.String()+"\n")
-	if err != nil {
-		return err
-	}
-
-	return nil
+	return err
 }
 
 func suppressDefaults(
@@ -164,22 +285,25 @@ func New(handlerOptions *slog.HandlerOptions, options ...Option) *Handler {
 		handlerOptions = &slog.HandlerOptions{}
 	}
 
-	buf := &bytes.Buffer{}
 	handler := &Handler{
-		b: buf,
-		h: slog.NewJSONHandler(buf, &slog.HandlerOptions{
-			Level:       handlerOptions.Level,
-			AddSource:   handlerOptions.AddSource,
-			ReplaceAttr: suppressDefaults(handlerOptions.ReplaceAttr),
-		}),
-		r: handlerOptions.ReplaceAttr,
-		m: &sync.Mutex{},
+		opts:              handlerOptions,
+		r:                 handlerOptions.ReplaceAttr,
+		formatter:         JSONFormatter{},
+		addSource:         handlerOptions.AddSource,
+		sourceFormatter:   defaultSourceFormatter,
+		truncateThreshold: defaultTruncateThreshold,
 	}
 
 	for _, opt := range options {
 		opt(handler)
 	}
 
+	if handler.colorEnabled != nil {
+		handler.color = *handler.colorEnabled
+	} else {
+		handler.color = isTerminal(handler.writer)
+	}
+
 	return handler
 }
 
@@ -3,8 +3,10 @@ package prettylog
 import (
 	"bytes"
 	"context"
+	"io"
 	"log/slog"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 )
@@ -62,7 +64,7 @@ func TestHandle_OutputFormat(t *testing.T) {
 
 func TestHandle_WithAttrsInOutput(t *testing.T) {
 	var buf bytes.Buffer
-	h := New(nil, WithDestinationWriter(&buf))
+	h := New(&slog.HandlerOptions{Level: slog.LevelDebug}, WithDestinationWriter(&buf))
 
 	r := slog.NewRecord(time.Now(), slog.LevelDebug, "test msg", 0)
 	r.AddAttrs(slog.String("key", "value"), slog.Int("count", 42))
@@ -217,3 +219,55 @@ func TestHandle_LevelFormatting(t *testing.T) {
 		})
 	}
 }
+
+// syncBuffer wraps a bytes.Buffer with a mutex so concurrent Handle calls in
+// the tests below don't race on the destination writer itself.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (s *syncBuffer) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.buf.Write(p)
+}
+
+func TestHandle_ConcurrentCallsDontCorruptOutput(t *testing.T) {
+	var buf syncBuffer
+	h := New(nil, WithDestinationWriter(&buf))
+
+	const goroutines = 50
+	var wg sync.WaitGroup
+	wg.Add(goroutines)
+	for i := 0; i < goroutines; i++ {
+		go func(n int) {
+			defer wg.Done()
+			r := slog.NewRecord(time.Now(), slog.LevelInfo, "concurrent", 0)
+			r.AddAttrs(slog.Int("n", n))
+			if err := h.Handle(context.Background(), r); err != nil {
+				t.Errorf("Handle returned error: %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	lines := strings.Split(strings.TrimRight(buf.buf.String(), "\n"), "\n")
+	if len(lines) != goroutines {
+		t.Fatalf("expected %d lines, got %d", goroutines, len(lines))
+	}
+}
+
+func BenchmarkHandle_Concurrent(b *testing.B) {
+	h := New(nil, WithDestinationWriter(io.Discard))
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			r := slog.NewRecord(time.Now(), slog.LevelInfo, "bench", 0)
+			r.AddAttrs(slog.String("key", "value"), slog.Int("count", 42))
+			if err := h.Handle(context.Background(), r); err != nil {
+				b.Fatalf("Handle returned error: %v", err)
+			}
+		}
+	})
+}
@@ -0,0 +1,49 @@
+package prettylog
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+)
+
+// WithSourceFormatter overrides how the source attribute is rendered when
+// AddSource is enabled. Defaults to "<base file name>:<line>".
+func WithSourceFormatter(f func(*slog.Source) string) Option {
+	return func(h *Handler) {
+		h.sourceFormatter = f
+	}
+}
+
+func defaultSourceFormatter(s *slog.Source) string {
+	return fmt.Sprintf("%s:%d", filepath.Base(s.File), s.Line)
+}
+
+// extractSource pulls the slog.SourceKey attribute out of attrs (added by
+// the inner JSON handler when AddSource is enabled) and renders it via
+// h.sourceFormatter, so it can be surfaced as its own token instead of
+// staying buried in the trailing attrs blob.
+func (h *Handler) extractSource(attrs map[string]any) string {
+	raw, ok := attrs[slog.SourceKey]
+	if !ok {
+		return ""
+	}
+	delete(attrs, slog.SourceKey)
+
+	m, ok := raw.(map[string]any)
+	if !ok {
+		return ""
+	}
+
+	b, err := json.Marshal(m)
+	if err != nil {
+		return ""
+	}
+
+	var src slog.Source
+	if err := json.Unmarshal(b, &src); err != nil {
+		return ""
+	}
+
+	return h.sourceFormatter(&src)
+}
@@ -0,0 +1,64 @@
+package prettylog
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newRecordWithSource(level slog.Level, msg string) slog.Record {
+	pc, _, _, _ := runtime.Caller(1)
+	return slog.NewRecord(time.Now(), level, msg, pc)
+}
+
+func TestHandle_AddSourceRendersFileLineToken(t *testing.T) {
+	var buf bytes.Buffer
+	h := New(&slog.HandlerOptions{AddSource: true}, WithDestinationWriter(&buf))
+
+	r := newRecordWithSource(slog.LevelInfo, "hello")
+	if err := h.Handle(context.Background(), r); err != nil {
+		t.Fatalf("Handle returned error: %v", err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, "source_test.go:") {
+		t.Errorf("expected source file:line token, got: %s", out)
+	}
+	if strings.Contains(out, `"source"`) {
+		t.Errorf("expected source to be pulled out of the attrs blob, got: %s", out)
+	}
+}
+
+func TestHandle_NoSourceTokenWhenAddSourceDisabled(t *testing.T) {
+	var buf bytes.Buffer
+	h := New(nil, WithDestinationWriter(&buf))
+
+	r := newRecordWithSource(slog.LevelInfo, "hello")
+	if err := h.Handle(context.Background(), r); err != nil {
+		t.Fatalf("Handle returned error: %v", err)
+	}
+
+	if strings.Contains(buf.String(), "source_test.go:") {
+		t.Errorf("expected no source token when AddSource is false, got: %s", buf.String())
+	}
+}
+
+func TestHandle_WithSourceFormatter(t *testing.T) {
+	var buf bytes.Buffer
+	h := New(&slog.HandlerOptions{AddSource: true}, WithDestinationWriter(&buf), WithSourceFormatter(func(s *slog.Source) string {
+		return "CUSTOM:" + s.Function
+	}))
+
+	r := newRecordWithSource(slog.LevelInfo, "hello")
+	if err := h.Handle(context.Background(), r); err != nil {
+		t.Fatalf("Handle returned error: %v", err)
+	}
+
+	if !strings.Contains(buf.String(), "CUSTOM:") {
+		t.Errorf("expected custom source formatter output, got: %s", buf.String())
+	}
+}